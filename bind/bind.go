@@ -0,0 +1,348 @@
+//
+// Copyright (C) 2024 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/opts
+//
+
+// Package bind turns [opts.Option] into a small configuration system.
+// It materializes options for `S` from external sources -- environment
+// variables, command line flags and a config file -- driven by `opt`
+// struct tags declared on the exported fields of `S`.
+//
+//	type Config struct {
+//		Host string `opt:"host,env=CLIENT_HOST,flag=host,default=localhost,required"`
+//	}
+//
+//	bound, err := bind.Load[Config]("config.json")
+//	if err != nil {
+//		// ...
+//	}
+//
+//	c := Config{}
+//	if err := opts.Apply(&c, bound); err != nil {
+//		// ...
+//	}
+//
+// Values are layered with a fixed precedence, lowest to highest:
+// `default` tag, config file, environment variable, CLI flag. The caller
+// decides how programmatic [opts.Option] compose with the bound ones --
+// appending them after `bound` gives them the highest precedence of all.
+//
+// [Load] only ships decoding for JSON files and parsing through the stdlib
+// `flag` package -- that is what is built in, not the whole of what `bind`
+// supports. A config file in another format (YAML, TOML, ...) is handled by
+// writing a [Decoder] for it and calling [LoadWith]; a flag library other
+// than stdlib `flag` (e.g. pflag) is handled by letting the caller parse
+// `args` itself and calling [LoadValues] with the resolved flag values.
+// [Load] and [LoadWith] are convenience wrappers around [LoadValues] for the
+// common case.
+package bind
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/fogfish/opts"
+)
+
+// Tag is the struct tag name recognized by [Load].
+const Tag = "opt"
+
+// binding is the parsed representation of a single `opt` struct tag.
+type binding struct {
+	field    string
+	name     string
+	env      string
+	flag     string
+	def      string
+	required bool
+}
+
+// parseTag decodes a `opt:"name,env=NAME,flag=name,default=value,required"` tag.
+func parseTag(field, raw string) binding {
+	b := binding{field: field}
+
+	for i, part := range strings.Split(raw, ",") {
+		if i == 0 {
+			b.name = part
+			continue
+		}
+
+		switch {
+		case part == "required":
+			b.required = true
+		case strings.HasPrefix(part, "env="):
+			b.env = strings.TrimPrefix(part, "env=")
+		case strings.HasPrefix(part, "flag="):
+			b.flag = strings.TrimPrefix(part, "flag=")
+		case strings.HasPrefix(part, "default="):
+			b.def = strings.TrimPrefix(part, "default=")
+		}
+	}
+
+	if b.name == "" {
+		b.name = field
+	}
+
+	return b
+}
+
+// Decoder turns the raw content of a config file into a flat map of values,
+// keyed by the option's `opt` name. [DecodeJSON] is the only [Decoder] the
+// package ships; pass a different one to [LoadWith] to support YAML, TOML or
+// any other format without this package having to vendor a parser for it.
+type Decoder func(data []byte) (map[string]any, error)
+
+// DecodeJSON is the built-in [Decoder] used by [Load].
+func DecodeJSON(data []byte) (map[string]any, error) {
+	raw := map[string]any{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// Load walks the exported fields of `S`, resolves each `opt`-tagged field
+// from (in increasing precedence) its `default`, `file`, environment
+// variable and CLI flag, and returns the corresponding [opts.Option]
+// sequence ready to be passed to [opts.Apply]. `file` is decoded with
+// [DecodeJSON]; use [LoadWith] to decode another format.
+//
+// `args`, when given, is parsed for the `flag`-tagged fields with the
+// stdlib `flag` package, e.g. `bind.Load[Config]("config.json",
+// os.Args[1:]...)`. Load never reads `os.Args` itself, so it stays usable
+// from a host program that parses its own flags (or from tests, which run
+// under `go test`'s own flag set) -- pass no `args` to resolve flag-tagged
+// fields from their `default`/file/env value only. Use [LoadValues] to
+// source flag values from a different flag library, such as pflag.
+func Load[S any](file string, args ...string) ([]opts.Option[S], error) {
+	return LoadWith[S](file, DecodeJSON, args...)
+}
+
+// LoadWith is [Load] parameterized by the [Decoder] used to read `file`,
+// so that config formats other than JSON (YAML, TOML, ...) can be
+// supported without this package depending on a parser for them.
+func LoadWith[S any](file string, decode Decoder, args ...string) ([]opts.Option[S], error) {
+	binds, err := bindings[S]()
+	if err != nil {
+		return nil, err
+	}
+
+	fromFile, err := loadFile(file, decode)
+	if err != nil {
+		return nil, err
+	}
+
+	fromFlags, err := parseArgs(binds, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return LoadValues[S](fromFile, fromFlags)
+}
+
+// LoadValues is the flag-library-agnostic core of [Load]: it resolves each
+// `opt`-tagged field of `S` from (in increasing precedence) its `default`,
+// `fromFile`, environment variable and `fromFlags`, and returns the
+// corresponding [opts.Option] sequence. A field marked `required` that
+// resolves to an empty value causes LoadValues to fail.
+//
+// `fromFile` and `fromFlags` are keyed by the option's `opt` name (not the
+// struct field name) and may be nil. Callers integrating a flag library
+// other than stdlib `flag` (e.g. pflag) parse `args` themselves and pass
+// the resolved values here instead of going through [Load]/[LoadWith].
+func LoadValues[S any](fromFile, fromFlags map[string]string) ([]opts.Option[S], error) {
+	binds, err := bindings[S]()
+	if err != nil {
+		return nil, err
+	}
+
+	options := make([]opts.Option[S], 0, len(binds))
+	for _, b := range binds {
+		value := b.def
+
+		if v, has := fromFile[b.name]; has && v != "" {
+			value = v
+		}
+
+		if b.env != "" {
+			if v, has := os.LookupEnv(b.env); has {
+				value = v
+			}
+		}
+
+		if v, has := fromFlags[b.name]; has && v != "" {
+			value = v
+		}
+
+		if value == "" {
+			if b.required {
+				return nil, fmt.Errorf("bind: option %s is required, set env %s or flag -%s", b.name, b.env, b.flag)
+			}
+			continue
+		}
+
+		option, err := newOption[S](b, value)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, option)
+	}
+
+	return options, nil
+}
+
+// bindings parses the `opt` tags of the exported fields of `S`.
+func bindings[S any]() ([]binding, error) {
+	var s S
+	t := reflect.TypeOf(s)
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("bind: %T is not a struct", s)
+	}
+
+	var binds []binding
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		raw, ok := f.Tag.Lookup(Tag)
+		if !ok || !f.IsExported() {
+			continue
+		}
+
+		binds = append(binds, parseTag(f.Name, raw))
+	}
+
+	return binds, nil
+}
+
+// parseArgs parses `args` with the stdlib `flag` package, registering a
+// string flag for every `flag`-tagged binding, and returns the resolved
+// values keyed by the option's `opt` name. Passing no `args` resolves every
+// flag-tagged field to "", leaving `default`/file/env to take over.
+func parseArgs(binds []binding, args []string) (map[string]string, error) {
+	fs := flag.NewFlagSet("bind", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	flags := map[string]*string{}
+	for _, b := range binds {
+		if b.flag != "" {
+			flags[b.name] = fs.String(b.flag, "", fmt.Sprintf("bind %s", b.name))
+		}
+	}
+
+	if len(args) > 0 {
+		if err := fs.Parse(args); err != nil {
+			return nil, fmt.Errorf("bind: unable to parse flags: %w", err)
+		}
+	}
+
+	values := make(map[string]string, len(flags))
+	for name, v := range flags {
+		values[name] = *v
+	}
+
+	return values, nil
+}
+
+// loadFile reads a config file and decodes it with `decode` into a flat
+// string map. An empty path or a missing file resolves to an empty map
+// rather than an error.
+func loadFile(file string, decode Decoder) (map[string]string, error) {
+	if file == "" {
+		return map[string]string{}, nil
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]string{}, nil
+		}
+		return nil, fmt.Errorf("bind: unable to read %s: %w", file, err)
+	}
+
+	raw, err := decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("bind: unable to parse %s: %w", file, err)
+	}
+
+	values := make(map[string]string, len(raw))
+	for k, v := range raw {
+		values[k] = fmt.Sprintf("%v", v)
+	}
+
+	return values, nil
+}
+
+// newOption builds the [opts.Option] that assigns `value`, converted to the
+// target field's type, to the field described by `b`. It mirrors
+// [opts.ForName] -- resolving a real lens through `optics.ForProduct1` for
+// `b.field` -- so the returned option participates in `check` (and
+// therefore [opts.Required] and the constraints built on it) like any other
+// option in the package, instead of being a raw reflective setter that
+// never reports a missing value.
+func newOption[S any](b binding, value string) (opts.Option[S], error) {
+	var s S
+	t := reflect.TypeOf(s)
+
+	sf, ok := t.FieldByName(b.field)
+	if !ok {
+		return nil, fmt.Errorf("bind: option %s has no field %s", b.name, b.field)
+	}
+
+	switch sf.Type {
+	case reflect.TypeOf(string("")):
+		return opts.ForName[S, string](b.field)(value), nil
+
+	case reflect.TypeOf(bool(false)):
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("bind: option %s is not a bool: %w", b.name, err)
+		}
+		return opts.ForName[S, bool](b.field)(v), nil
+
+	case reflect.TypeOf(int(0)):
+		v, err := strconv.ParseInt(value, 10, strconv.IntSize)
+		if err != nil {
+			return nil, fmt.Errorf("bind: option %s is not an int: %w", b.name, err)
+		}
+		return opts.ForName[S, int](b.field)(int(v)), nil
+
+	case reflect.TypeOf(int64(0)):
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bind: option %s is not an int64: %w", b.name, err)
+		}
+		return opts.ForName[S, int64](b.field)(v), nil
+
+	case reflect.TypeOf(uint(0)):
+		v, err := strconv.ParseUint(value, 10, strconv.IntSize)
+		if err != nil {
+			return nil, fmt.Errorf("bind: option %s is not a uint: %w", b.name, err)
+		}
+		return opts.ForName[S, uint](b.field)(uint(v)), nil
+
+	case reflect.TypeOf(uint64(0)):
+		v, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bind: option %s is not a uint64: %w", b.name, err)
+		}
+		return opts.ForName[S, uint64](b.field)(v), nil
+
+	case reflect.TypeOf(float64(0)):
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bind: option %s is not a float64: %w", b.name, err)
+		}
+		return opts.ForName[S, float64](b.field)(v), nil
+
+	default:
+		return nil, fmt.Errorf("bind: option %s has unsupported type %s", b.name, sf.Type)
+	}
+}
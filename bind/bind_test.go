@@ -0,0 +1,171 @@
+//
+// Copyright (C) 2024 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/opts
+//
+
+package bind_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/fogfish/it/v2"
+	"github.com/fogfish/opts"
+	"github.com/fogfish/opts/bind"
+)
+
+type Config struct {
+	Host    string `opt:"host,env=TEST_BIND_HOST,default=localhost"`
+	Port    int    `opt:"port,env=TEST_BIND_PORT,default=8080"`
+	Secure  bool   `opt:"secure,env=TEST_BIND_SECURE"`
+	Ignored string
+}
+
+func New(opt ...opts.Option[Config]) (*Config, error) {
+	c := Config{}
+	if err := opts.Apply(&c, opt); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func TestLoadDefault(t *testing.T) {
+	bound, err := bind.Load[Config]("")
+	it.Then(t).Should(it.Nil(err))
+
+	c, err := New(bound...)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(c.Host, "localhost"),
+		it.Equal(c.Port, 8080),
+	)
+}
+
+func TestLoadEnv(t *testing.T) {
+	os.Setenv("TEST_BIND_HOST", "example.com")
+	os.Setenv("TEST_BIND_SECURE", "true")
+	defer os.Unsetenv("TEST_BIND_HOST")
+	defer os.Unsetenv("TEST_BIND_SECURE")
+
+	bound, err := bind.Load[Config]("")
+	it.Then(t).Should(it.Nil(err))
+
+	c, err := New(bound...)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(c.Host, "example.com"),
+		it.Equal(c.Secure, true),
+	)
+}
+
+func TestLoadRequired(t *testing.T) {
+	type Required struct {
+		Token string `opt:"token,env=TEST_BIND_TOKEN,required"`
+	}
+
+	_, err := bind.Load[Required]("")
+	it.Then(t).ShouldNot(it.Nil(err))
+}
+
+func TestLoadNotStruct(t *testing.T) {
+	_, err := bind.Load[string]("")
+	it.Then(t).ShouldNot(it.Nil(err))
+}
+
+func TestLoadIgnoresForeignArgs(t *testing.T) {
+	// Load never reads os.Args, so the flags it does not own that `go test`
+	// itself injects there (-test.v, -test.run, ...) never reach it.
+	bound, err := bind.Load[Config]("")
+	it.Then(t).Should(it.Nil(err))
+
+	_, err = New(bound...)
+	it.Then(t).Should(it.Nil(err))
+}
+
+func TestLoadFlag(t *testing.T) {
+	type Flagged struct {
+		Host string `opt:"host,flag=host,default=localhost"`
+	}
+
+	bound, err := bind.Load[Flagged]("", "-host", "flagged.example.com")
+	it.Then(t).Should(it.Nil(err))
+
+	c := Flagged{}
+	err = opts.Apply(&c, bound)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(c.Host, "flagged.example.com"),
+	)
+}
+
+// decodeKV is a minimal `key=value`-per-line [bind.Decoder], standing in
+// for a format this package does not ship a decoder for (YAML, TOML, ...).
+func decodeKV(data []byte) (map[string]any, error) {
+	values := map[string]any{}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("malformed line %q", line)
+		}
+		values[kv[0]] = kv[1]
+	}
+	return values, nil
+}
+
+func TestLoadWithDecoder(t *testing.T) {
+	file := t.TempDir() + "/config.kv"
+	it.Then(t).Should(it.Nil(os.WriteFile(file, []byte("host=kv.example.com\n"), 0o644)))
+
+	bound, err := bind.LoadWith[Config](file, decodeKV)
+	it.Then(t).Should(it.Nil(err))
+
+	c, err := New(bound...)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(c.Host, "kv.example.com"),
+	)
+}
+
+func TestLoadValues(t *testing.T) {
+	bound, err := bind.LoadValues[Config](
+		map[string]string{"host": "fromfile.example.com"},
+		map[string]string{"port": "9090"},
+	)
+	it.Then(t).Should(it.Nil(err))
+
+	c, err := New(bound...)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(c.Host, "fromfile.example.com"),
+		it.Equal(c.Port, 9090),
+	)
+}
+
+func TestLoadOptionsParticipateInCheck(t *testing.T) {
+	bound, err := bind.Load[Config]("")
+	it.Then(t).Should(it.Nil(err))
+
+	// bound options carry a real lens, so opts.Required must see that an
+	// un-applied instance still has its field unset ...
+	unapplied := Config{}
+	err = opts.Required(&unapplied, bound...)
+	it.Then(t).ShouldNot(it.Nil(err))
+
+	// ... and that it is satisfied once the very same options are applied.
+	applied := Config{}
+	err = opts.Apply(&applied, bound)
+	it.Then(t).Should(it.Nil(err))
+
+	err = opts.Required(&applied, bound...)
+	it.Then(t).Should(it.Nil(err))
+}
@@ -0,0 +1,129 @@
+//
+// Copyright (C) 2024 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/opts
+//
+
+package opts
+
+import "fmt"
+
+// named is implemented by options ([opt], [mk]) that carry a display name,
+// used by constraints to build readable violation messages.
+type named interface{ optName() string }
+
+func nameOf[S any](o Option[S]) string {
+	if n, ok := o.(named); ok {
+		return n.optName()
+	}
+	return "option"
+}
+
+func namesOf[S any](opts []Option[S]) string {
+	names := make([]string, len(opts))
+	for i, o := range opts {
+		names[i] = nameOf(o)
+	}
+
+	s := ""
+	for i, n := range names {
+		if i > 0 {
+			s += ", "
+		}
+		s += n
+	}
+	return s
+}
+
+// [OneOf] is a constraint requiring that exactly one of the given options is
+// defined within the checked instance of `S`. It participates in the `check`
+// phase, use it together with [Required]:
+//
+//	err := opts.Required(&c, opts.OneOf(withTLSConfig(x), withInsecureSkipVerify(true)))
+func OneOf[S any](opts ...Option[S]) Option[S] { return oneOf[S](opts) }
+
+type oneOf[S any] []Option[S]
+
+//lint:ignore U1000 false positive
+func (c oneOf[S]) apply(s *S) error { return nil }
+
+//lint:ignore U1000 false positive
+func (c oneOf[S]) check(s *S) error {
+	set := 0
+	for _, o := range c {
+		if o.check(s) == nil {
+			set++
+		}
+	}
+
+	if set != 1 {
+		return fmt.Errorf("exactly one option is required: %s", namesOf[S](c))
+	}
+	return nil
+}
+
+// [AtLeastOne] is a constraint requiring that at least one of the given
+// options is defined within the checked instance of `S`.
+func AtLeastOne[S any](opts ...Option[S]) Option[S] { return atLeastOne[S](opts) }
+
+type atLeastOne[S any] []Option[S]
+
+//lint:ignore U1000 false positive
+func (c atLeastOne[S]) apply(s *S) error { return nil }
+
+//lint:ignore U1000 false positive
+func (c atLeastOne[S]) check(s *S) error {
+	for _, o := range c {
+		if o.check(s) == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("at least one option is required: %s", namesOf[S](c))
+}
+
+// [MutuallyExclusive] is a constraint requiring that no more than one of the
+// given options is defined within the checked instance of `S`.
+func MutuallyExclusive[S any](opts ...Option[S]) Option[S] { return mutuallyExclusive[S](opts) }
+
+type mutuallyExclusive[S any] []Option[S]
+
+//lint:ignore U1000 false positive
+func (c mutuallyExclusive[S]) apply(s *S) error { return nil }
+
+//lint:ignore U1000 false positive
+func (c mutuallyExclusive[S]) check(s *S) error {
+	set := 0
+	for _, o := range c {
+		if o.check(s) == nil {
+			set++
+		}
+	}
+
+	if set > 1 {
+		return fmt.Errorf("options are mutually exclusive: %s", namesOf[S](c))
+	}
+	return nil
+}
+
+// [Implies] is a constraint requiring that `b` is defined whenever `a` is
+// defined within the checked instance of `S`.
+func Implies[S any](a, b Option[S]) Option[S] { return implies[S]{a, b} }
+
+type implies[S any] struct{ a, b Option[S] }
+
+//lint:ignore U1000 false positive
+func (c implies[S]) apply(s *S) error { return nil }
+
+//lint:ignore U1000 false positive
+func (c implies[S]) check(s *S) error {
+	if c.a.check(s) != nil {
+		return nil
+	}
+
+	if err := c.b.check(s); err != nil {
+		return fmt.Errorf("option %s requires %s: %w", nameOf(c.a), nameOf(c.b), err)
+	}
+	return nil
+}
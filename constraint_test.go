@@ -0,0 +1,113 @@
+//
+// Copyright (C) 2024 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/opts
+//
+
+package opts_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/fogfish/it/v2"
+	"github.com/fogfish/opts"
+)
+
+func TestOneOf(t *testing.T) {
+	withHost := opts.ForType[Client, Host]()
+	withAddr := opts.ForName[Client, string]("addr")
+
+	t.Run("Satisfied", func(t *testing.T) {
+		c, err := New(withHost(kHost))
+		it.Then(t).Should(it.Nil(err))
+
+		err = opts.Required(c, opts.OneOf(withHost(""), withAddr("")))
+		it.Then(t).Should(it.Nil(err))
+	})
+
+	t.Run("None", func(t *testing.T) {
+		c, err := New()
+		it.Then(t).Should(it.Nil(err))
+
+		err = opts.Required(c, opts.OneOf(withHost(""), withAddr("")))
+		it.Then(t).ShouldNot(it.Nil(err))
+	})
+
+	t.Run("Both", func(t *testing.T) {
+		c, err := New(withHost(kHost), withAddr(kAddr))
+		it.Then(t).Should(it.Nil(err))
+
+		err = opts.Required(c, opts.OneOf(withHost(""), withAddr("")))
+		it.Then(t).ShouldNot(it.Nil(err))
+	})
+}
+
+func TestAtLeastOne(t *testing.T) {
+	withHost := opts.ForType[Client, Host]()
+	withAddr := opts.ForName[Client, string]("addr")
+
+	c, err := New(withAddr(kAddr))
+	it.Then(t).Should(it.Nil(err))
+
+	err = opts.Required(c, opts.AtLeastOne(withHost(""), withAddr("")))
+	it.Then(t).Should(it.Nil(err))
+
+	c, err = New()
+	it.Then(t).Should(it.Nil(err))
+
+	err = opts.Required(c, opts.AtLeastOne(withHost(""), withAddr("")))
+	it.Then(t).ShouldNot(it.Nil(err))
+}
+
+func TestMutuallyExclusive(t *testing.T) {
+	withHost := opts.ForType[Client, Host]()
+	withAddr := opts.ForName[Client, string]("addr")
+
+	c, err := New(withHost(kHost), withAddr(kAddr))
+	it.Then(t).Should(it.Nil(err))
+
+	err = opts.Required(c, opts.MutuallyExclusive(withHost(""), withAddr("")))
+	it.Then(t).ShouldNot(it.Nil(err))
+}
+
+func TestImplies(t *testing.T) {
+	withHost := opts.ForType[Client, Host]()
+	withAddr := opts.ForName[Client, string]("addr")
+
+	t.Run("Satisfied", func(t *testing.T) {
+		c, err := New(withHost(kHost), withAddr(kAddr))
+		it.Then(t).Should(it.Nil(err))
+
+		err = opts.Required(c, opts.Implies(withHost(""), withAddr("")))
+		it.Then(t).Should(it.Nil(err))
+	})
+
+	t.Run("Violated", func(t *testing.T) {
+		c, err := New(withHost(kHost))
+		it.Then(t).Should(it.Nil(err))
+
+		err = opts.Required(c, opts.Implies(withHost(""), withAddr("")))
+		it.Then(t).ShouldNot(it.Nil(err))
+	})
+}
+
+func TestRequiredAggregatesErrors(t *testing.T) {
+	withHost := opts.ForType[Client, Host]()
+	withAddr := opts.ForName[Client, string]("addr")
+
+	c, err := New()
+	it.Then(t).Should(it.Nil(err))
+
+	err = opts.Required(c, withHost(""), withAddr(""))
+	it.Then(t).ShouldNot(it.Nil(err))
+
+	msg := fmt.Sprint(err)
+	it.Then(t).Should(
+		it.Equal(strings.Contains(msg, "undefined option host"), true),
+		it.Equal(strings.Contains(msg, "undefined option addr"), true),
+	)
+}
@@ -0,0 +1,154 @@
+//
+// Copyright (C) 2024 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/opts
+//
+
+package opts
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// OptionInfo describes a single option registered for discoverability via
+// [Describe]. It is the unit returned by [Manifest] and consumed by
+// [RenderHelp] and [RenderJSONSchema] to auto-generate help text or a JSON
+// Schema document from a single source of truth.
+type OptionInfo struct {
+	Name        string
+	Type        string
+	Description string
+	Required    bool
+	Default     string
+}
+
+// DescribeMeta carries the optional metadata [Describe] attaches to an
+// option, beyond its name and human-readable description.
+type DescribeMeta struct {
+	Required bool
+	Default  string
+}
+
+var (
+	manifestMu sync.Mutex
+	manifest   = map[reflect.Type][]OptionInfo{}
+)
+
+// Describe attaches a human-readable description to an option constructor
+// (as produced by [ForType], [ForName] or [Opt]) and registers it so that it
+// appears in [Manifest], [RenderHelp] and [RenderJSONSchema] for `S`.
+//
+//	var WithHost = opts.Describe[Client]("host", "hostname of the remote endpoint",
+//		opts.ForName[Client, string]("host"),
+//		opts.DescribeMeta{Required: true, Default: "localhost"},
+//	)
+func Describe[S, A any](name, help string, mk func(A) Option[S], meta ...DescribeMeta) func(A) Option[S] {
+	info := OptionInfo{
+		Name:        name,
+		Type:        fmt.Sprintf("%T", *new(A)),
+		Description: help,
+	}
+	if len(meta) == 1 {
+		info.Required = meta[0].Required
+		info.Default = meta[0].Default
+	}
+
+	manifestMu.Lock()
+	t := reflect.TypeOf(*new(S))
+	manifest[t] = append(manifest[t], info)
+	manifestMu.Unlock()
+
+	return mk
+}
+
+// Manifest returns [OptionInfo] for every option registered for `S` via
+// [Describe], in registration order.
+func Manifest[S any]() []OptionInfo {
+	manifestMu.Lock()
+	defer manifestMu.Unlock()
+
+	t := reflect.TypeOf(*new(S))
+	info := manifest[t]
+	out := make([]OptionInfo, len(info))
+	copy(out, info)
+	return out
+}
+
+// RenderHelp writes `--help`-style text describing every option registered
+// for `S` via [Describe], marking required options and showing their
+// default value where known.
+func RenderHelp[S any](w io.Writer) error {
+	for _, info := range Manifest[S]() {
+		suffix := ""
+		if info.Required {
+			suffix += " (required)"
+		}
+		if info.Default != "" {
+			suffix += fmt.Sprintf(" (default %s)", info.Default)
+		}
+
+		if _, err := fmt.Fprintf(w, "  --%s %s%s\n\t%s\n", info.Name, info.Type, suffix, info.Description); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenderJSONSchema writes a JSON Schema document describing every option
+// registered for `S` via [Describe], including a top-level `required` array
+// and each option's `default` where known.
+func RenderJSONSchema[S any](w io.Writer) error {
+	type property struct {
+		Type        string `json:"type"`
+		Description string `json:"description,omitempty"`
+		Default     string `json:"default,omitempty"`
+	}
+
+	schema := struct {
+		Type       string              `json:"type"`
+		Properties map[string]property `json:"properties"`
+		Required   []string            `json:"required,omitempty"`
+	}{
+		Type:       "object",
+		Properties: map[string]property{},
+	}
+
+	for _, info := range Manifest[S]() {
+		schema.Properties[info.Name] = property{
+			Type:        jsonType(info.Type),
+			Description: info.Description,
+			Default:     info.Default,
+		}
+		if info.Required {
+			schema.Required = append(schema.Required, info.Name)
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(schema)
+}
+
+// jsonType maps a Go type name, as reported by [OptionInfo.Type], to its
+// closest JSON Schema primitive.
+func jsonType(goType string) string {
+	switch goType {
+	case "string":
+		return "string"
+	case "bool":
+		return "boolean"
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64":
+		return "integer"
+	case "float32", "float64":
+		return "number"
+	default:
+		return "string"
+	}
+}
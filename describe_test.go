@@ -0,0 +1,77 @@
+//
+// Copyright (C) 2024 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/opts
+//
+
+package opts_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fogfish/it/v2"
+	"github.com/fogfish/opts"
+)
+
+type Described struct {
+	host string
+	port int
+}
+
+var withDescribedHost = opts.Describe[Described]("host", "hostname of the remote endpoint",
+	opts.ForName[Described, string]("host"),
+	opts.DescribeMeta{Required: true, Default: "localhost"},
+)
+
+var withDescribedPort = opts.Describe[Described]("port", "port number of the remote endpoint",
+	opts.ForName[Described, int]("port"),
+)
+
+func TestManifest(t *testing.T) {
+	_ = withDescribedHost
+	_ = withDescribedPort
+
+	info := opts.Manifest[Described]()
+
+	it.Then(t).Should(
+		it.Equal(len(info), 2),
+		it.Equal(info[0].Name, "host"),
+		it.Equal(info[0].Description, "hostname of the remote endpoint"),
+		it.Equal(info[0].Required, true),
+		it.Equal(info[0].Default, "localhost"),
+		it.Equal(info[1].Required, false),
+	)
+}
+
+func TestRenderHelp(t *testing.T) {
+	_ = withDescribedHost
+
+	var buf bytes.Buffer
+	err := opts.RenderHelp[Described](&buf)
+
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(strings.Contains(buf.String(), "--host"), true),
+		it.Equal(strings.Contains(buf.String(), "(required)"), true),
+		it.Equal(strings.Contains(buf.String(), "(default localhost)"), true),
+	)
+}
+
+func TestRenderJSONSchema(t *testing.T) {
+	_ = withDescribedHost
+
+	var buf bytes.Buffer
+	err := opts.RenderJSONSchema[Described](&buf)
+
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(strings.Contains(buf.String(), `"host"`), true),
+		it.Equal(strings.Contains(buf.String(), `"type": "object"`), true),
+		it.Equal(strings.Contains(buf.String(), `"required"`), true),
+		it.Equal(strings.Contains(buf.String(), `"default": "localhost"`), true),
+	)
+}
@@ -0,0 +1,47 @@
+//
+// Copyright (C) 2024 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/opts
+//
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fogfish/opts"
+	"github.com/fogfish/opts/bind"
+)
+
+// Configuration type, fields are tagged with the source they are bound from.
+type Client struct {
+	Host string `opt:"host,env=CLIENT_HOST,flag=host,default=localhost"`
+	Port int    `opt:"port,env=CLIENT_PORT,flag=port,default=8080"`
+}
+
+// Factory creates configuration instance
+func New(opt ...opts.Option[Client]) (*Client, error) {
+	c := Client{}
+
+	if err := opts.Apply(&c, opt); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func main() {
+	bound, err := bind.Load[Client]("", os.Args[1:]...)
+	if err != nil {
+		panic(err)
+	}
+
+	c, err := New(bound...)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("==> %+v\n", c)
+}
@@ -0,0 +1,90 @@
+//
+// Copyright (C) 2024 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/opts
+//
+
+package opts
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/fogfish/golem/optics"
+)
+
+// [ForField] is helper function to generate functional option for configuring
+// attribute of type `A` at instances `S`, same as [ForName] but the target
+// field is selected with a compile-time-checked pointer selector instead of
+// a stringly-typed name.
+//
+// Clients typically use:
+//
+//	type Client struct{ host string }
+//
+//	var WithHost = opts.ForField(func(c *Client) *string { return &c.host })
+//
+// The selector is evaluated once, against a zero value of `S`, to locate the
+// target field; refactoring the field (renaming it, changing its type)
+// therefore fails to compile instead of failing silently at runtime, unlike
+// [ForName].
+func ForField[S, A any](sel func(*S) *A, config ...func(*S, A) error) func(A) Option[S] {
+	var zero S
+	name := fieldName(&zero, sel)
+	lens := optics.ForProduct1[S, A](name)
+
+	var f func(*S, A) error
+	if len(config) == 1 {
+		f = config[0]
+	}
+
+	return func(value A) Option[S] {
+		return opt[S, A]{
+			name:  name,
+			value: value,
+			lens:  lens,
+			f:     f,
+		}
+	}
+}
+
+// fieldName resolves the struct field name targeted by selector `sel`,
+// by comparing the address it returns against the offset of each field of
+// `s`.
+func fieldName[S, A any](s *S, sel func(*S) *A) string {
+	base := reflect.ValueOf(s).Pointer()
+	target := reflect.ValueOf(sel(s)).Pointer()
+	offset := target - base
+
+	t := reflect.TypeOf(*s)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Offset == offset {
+			return f.Name
+		}
+	}
+
+	panic(fmt.Errorf("opts: ForField selector does not target a field of %T", *s))
+}
+
+// fieldNameByType resolves the name of the first field of `S` whose type is
+// `A`, giving [ForType] the same canonical, per-field display name that
+// [ForName] and [ForField] already carry -- instead of the Go type name it
+// used to fall back to -- so that options built through any of the three
+// constructors for the same field compare equal by name (see [named],
+// used by [Profile.Diff] and [Profile.Conflicts]). Falls back to the Go type
+// name of `A` when no field of that exact type is found.
+func fieldNameByType[S, A any]() string {
+	t := reflect.TypeOf(*new(S))
+	at := reflect.TypeOf(*new(A))
+
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Type == at {
+			return t.Field(i).Name
+		}
+	}
+
+	return fmt.Sprintf("%T", *new(A))
+}
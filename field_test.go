@@ -0,0 +1,51 @@
+//
+// Copyright (C) 2024 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/opts
+//
+
+package opts_test
+
+import (
+	"testing"
+
+	"github.com/fogfish/it/v2"
+	"github.com/fogfish/opts"
+)
+
+func TestForField(t *testing.T) {
+	t.Run("Field", func(t *testing.T) {
+		withAddr := opts.ForField(func(c *Client) *string { return &c.addr })
+		c, err := New(withAddr(kAddr))
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(c.addr, kAddr),
+		)
+	})
+
+	t.Run("WithConfig", func(t *testing.T) {
+		withAddr := opts.ForField(
+			func(c *Client) *string { return &c.addr },
+			func(c *Client, a string) error { return nil },
+		)
+		c, err := New(withAddr(kAddr))
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(c.addr, kAddr),
+		)
+	})
+
+	t.Run("Required", func(t *testing.T) {
+		withAddr := opts.ForField(func(c *Client) *string { return &c.addr })
+
+		c, err := New()
+		it.Then(t).Should(it.Nil(err))
+
+		err = opts.Required(c, withAddr(""))
+		it.Then(t).ShouldNot(it.Nil(err))
+	})
+}
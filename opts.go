@@ -25,6 +25,7 @@
 package opts
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -62,6 +63,7 @@ type Option[S any] interface {
 //	})
 func ForType[S, A any](config ...func(*S, A) error) func(A) Option[S] {
 	lens := optics.ForProduct1[S, A]()
+	name := fieldNameByType[S, A]()
 
 	var f func(*S, A) error
 	if len(config) == 1 {
@@ -70,7 +72,7 @@ func ForType[S, A any](config ...func(*S, A) error) func(A) Option[S] {
 
 	return func(value A) Option[S] {
 		return opt[S, A]{
-			name:  fmt.Sprintf("%T", *new(A)),
+			name:  name,
 			value: value,
 			lens:  lens,
 			f:     f,
@@ -170,6 +172,8 @@ func (opt opt[S, A]) check(s *S) error {
 	return nil
 }
 
+func (opt opt[S, A]) optName() string { return opt.name }
+
 // Join multiple options to single one, creating defaults and presets.
 func Join[S any](opts ...Option[S]) Option[S] { return options[S](opts) }
 
@@ -186,7 +190,7 @@ func Use[S, A, T any](f func(...Option[T]) (A, error)) func(...Option[T]) Option
 	lens := optics.ForProduct1[S, A]()
 
 	return func(opts ...Option[T]) Option[S] {
-		return make[S, A, T]{
+		return mk[S, A, T]{
 			name: fmt.Sprintf("%T", *new(A)),
 			opts: opts,
 			lens: lens,
@@ -195,7 +199,7 @@ func Use[S, A, T any](f func(...Option[T]) (A, error)) func(...Option[T]) Option
 	}
 }
 
-type make[S, A, T any] struct {
+type mk[S, A, T any] struct {
 	name string
 	opts options[T]
 	lens optics.Lens[S, A]
@@ -203,7 +207,7 @@ type make[S, A, T any] struct {
 }
 
 //lint:ignore U1000 false positive
-func (opt make[S, A, T]) apply(s *S) error {
+func (opt mk[S, A, T]) apply(s *S) error {
 	a, err := opt.f(opt.opts)
 	if err != nil {
 		return err
@@ -214,7 +218,7 @@ func (opt make[S, A, T]) apply(s *S) error {
 }
 
 //lint:ignore U1000 false positive
-func (opt make[S, A, T]) check(s *S) error {
+func (opt mk[S, A, T]) check(s *S) error {
 	a := opt.lens.Get(s)
 
 	if reflect.ValueOf(a).IsZero() {
@@ -224,6 +228,8 @@ func (opt make[S, A, T]) check(s *S) error {
 	return nil
 }
 
+func (opt mk[S, A, T]) optName() string { return opt.name }
+
 // [FMap] is a helper function for generating functional options to configure
 // attributes within instances of `S` using input type 'T'.
 func FMap[S, T any](f func(*S, T) error) func(T) Option[S] {
@@ -276,6 +282,8 @@ func (opt from[S]) check(s *S) error { return nil }
 func Apply[S any](s *S, opts []Option[S]) error { return options[S](opts).apply(s) }
 
 // [Required] checks that mandatory parameters are defined within instance of `S`.
+// All supplied options are checked, the returned error joins every violation
+// (see [errors.Join]) instead of failing on the first one.
 func Required[S any](s *S, opts ...Option[S]) error { return options[S](opts).check(s) }
 
 type options[S any] []Option[S]
@@ -290,10 +298,11 @@ func (opts options[S]) apply(s *S) error {
 }
 
 func (opts options[S]) check(s *S) error {
+	var errs []error
 	for _, opt := range opts {
 		if err := opt.check(s); err != nil {
-			return err
+			errs = append(errs, err)
 		}
 	}
-	return nil
+	return errors.Join(errs...)
 }
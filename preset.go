@@ -0,0 +1,121 @@
+//
+// Copyright (C) 2024 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/opts
+//
+
+package opts
+
+// Profile is a named, inspectable group of options, built with [Preset] and
+// layered with [Extend]. Unlike [Join], which flattens options into an
+// opaque [Option], a Profile keeps its identity and its own option sequence
+// around for inspection -- useful for libraries that ship curated defaults
+// (dev/prod profiles) on top of user-supplied options.
+type Profile[S any] struct {
+	name string
+	opts []Option[S]
+}
+
+// [Preset] names a sequence of options, creating a reusable, inspectable
+// default or profile.
+//
+//	var Production = opts.Preset[Config]("production",
+//		withTimeout(30*time.Second),
+//		withRetries(3),
+//	)
+func Preset[S any](name string, opts ...Option[S]) *Profile[S] {
+	return &Profile[S]{name: name, opts: opts}
+}
+
+//lint:ignore U1000 false positive
+func (p *Profile[S]) apply(s *S) error { return options[S](p.opts).apply(s) }
+
+//lint:ignore U1000 false positive
+func (p *Profile[S]) check(s *S) error { return options[S](p.opts).check(s) }
+
+// Name returns the name the profile was created with.
+func (p *Profile[S]) Name() string { return p.name }
+
+// Options returns the options held by the profile, in application order.
+func (p *Profile[S]) Options() []Option[S] {
+	return append([]Option[S]{}, p.opts...)
+}
+
+// [Extend] creates a new, derived [Profile] that layers `override` on top of
+// `base`. Options are applied in order, so fields written by `override` win
+// over the same field written by `base` (last-write-wins).
+func Extend[S any](base *Profile[S], override ...Option[S]) *Profile[S] {
+	opts := append(append([]Option[S]{}, base.opts...), override...)
+	return &Profile[S]{name: base.name, opts: opts}
+}
+
+// Diff reports the options of the profile that write a field not written by
+// `other`, identified by field name. Options that carry no field identity
+// (such as ones built with [From] or [FMap]) cannot be compared this way --
+// they are always reported as present in `p`, never matched against `other`.
+func (p *Profile[S]) Diff(other *Profile[S]) []OptionInfo {
+	seen := map[string]bool{}
+	for _, o := range other.opts {
+		if n, ok := o.(named); ok {
+			seen[n.optName()] = true
+		}
+	}
+
+	var out []OptionInfo
+	for _, o := range p.opts {
+		n, ok := o.(named)
+		if !ok {
+			out = append(out, OptionInfo{Name: "<unnamed>"})
+			continue
+		}
+
+		if name := n.optName(); !seen[name] {
+			out = append(out, OptionInfo{Name: name})
+		}
+	}
+	return out
+}
+
+// Conflicts reports the field names written by more than one option within
+// the profile, in the order they were first written. Since later options
+// win (last-write-wins), a non-empty result is a hint -- not necessarily an
+// error -- that the profile shadows one of its own defaults.
+//
+// Options that carry no field identity (such as ones built with [From] or
+// [FMap]) are excluded: they do not expose which field, if any, they write,
+// so two of them can never be reported as conflicting with each other.
+func (p *Profile[S]) Conflicts() []string {
+	count := map[string]int{}
+	var order []string
+
+	for _, o := range p.opts {
+		n, ok := o.(named)
+		if !ok {
+			continue
+		}
+
+		name := n.optName()
+		if count[name] == 0 {
+			order = append(order, name)
+		}
+		count[name]++
+	}
+
+	var out []string
+	for _, name := range order {
+		if count[name] > 1 {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// Materialize applies the profile to a zero value of `S` and returns the
+// result.
+func (p *Profile[S]) Materialize() (S, error) {
+	var s S
+	err := Apply(&s, p.opts)
+	return s, err
+}
@@ -0,0 +1,129 @@
+//
+// Copyright (C) 2024 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/opts
+//
+
+package opts_test
+
+import (
+	"testing"
+
+	"github.com/fogfish/it/v2"
+	"github.com/fogfish/opts"
+)
+
+func TestPreset(t *testing.T) {
+	withHost := opts.ForType[Client, Host]()
+	withAddr := opts.ForName[Client, string]("addr")
+
+	production := opts.Preset[Client]("production", withHost(kHost), withAddr(kAddr))
+
+	it.Then(t).Should(
+		it.Equal(production.Name(), "production"),
+		it.Equal(len(production.Options()), 2),
+	)
+
+	c, err := New(production)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(c.host, kHost),
+		it.Equal(c.addr, kAddr),
+	)
+}
+
+func TestExtend(t *testing.T) {
+	withHost := opts.ForType[Client, Host]()
+	withAddr := opts.ForName[Client, string]("addr")
+
+	base := opts.Preset[Client]("base", withHost(kHost))
+	staging := opts.Extend(base, withAddr(kAddr))
+
+	it.Then(t).Should(
+		it.Equal(staging.Name(), "base"),
+		it.Equal(len(staging.Options()), 2),
+		it.Equal(len(base.Options()), 1),
+	)
+
+	c, err := New(staging)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(c.host, kHost),
+		it.Equal(c.addr, kAddr),
+	)
+}
+
+func TestPresetDiff(t *testing.T) {
+	withHost := opts.ForType[Client, Host]()
+	withAddr := opts.ForName[Client, string]("addr")
+
+	a := opts.Preset[Client]("a", withHost(kHost), withAddr(kAddr))
+	b := opts.Preset[Client]("b", withHost(kHost))
+
+	diff := a.Diff(b)
+
+	it.Then(t).Should(
+		it.Equal(len(diff), 1),
+		it.Equal(diff[0].Name, "addr"),
+	)
+}
+
+func TestPresetConflicts(t *testing.T) {
+	withAddr := opts.ForName[Client, string]("addr")
+
+	p := opts.Preset[Client]("p", withAddr(kAddr), withAddr("override"))
+
+	it.Then(t).Should(
+		it.Equal(len(p.Conflicts()), 1),
+		it.Equal(p.Conflicts()[0], "addr"),
+	)
+}
+
+func TestPresetConflictsAcrossConstructors(t *testing.T) {
+	withHostByType := opts.ForType[Client, Host]()
+	withHostByName := opts.ForName[Client, Host]("host")
+
+	p := opts.Preset[Client]("p", withHostByType(kHost), withHostByName(kHost))
+
+	it.Then(t).Should(
+		it.Equal(len(p.Conflicts()), 1),
+		it.Equal(p.Conflicts()[0], "host"),
+	)
+}
+
+func TestPresetDiffAcrossConstructors(t *testing.T) {
+	withHostByType := opts.ForType[Client, Host]()
+	withHostByName := opts.ForName[Client, Host]("host")
+
+	a := opts.Preset[Client]("a", withHostByType(kHost))
+	b := opts.Preset[Client]("b", withHostByName(kHost))
+
+	it.Then(t).Should(
+		it.Equal(len(a.Diff(b)), 0),
+	)
+}
+
+func TestPresetConflictsIgnoresUnnamedOptions(t *testing.T) {
+	withHost := opts.FMap(func(c *Client, h Host) error { c.host = h; return nil })
+	withAddr := opts.FMap(func(c *Client, a string) error { c.addr = a; return nil })
+
+	p := opts.Preset[Client]("p", withHost(kHost), withAddr(kAddr))
+
+	it.Then(t).Should(
+		it.Equal(len(p.Conflicts()), 0),
+	)
+}
+
+func TestPresetMaterialize(t *testing.T) {
+	withHost := opts.ForType[Client, Host]()
+
+	p := opts.Preset[Client]("p", withHost(kHost))
+
+	c, err := p.Materialize()
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(c.host, kHost),
+	)
+}